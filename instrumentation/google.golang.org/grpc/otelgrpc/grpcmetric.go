@@ -3,12 +3,15 @@ package otelgrpc
 import (
 	"context"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/golang/protobuf/proto" // nolint:staticcheck
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/grpc"
 	grpc_codes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -21,81 +24,252 @@ const (
 	ServerLatencyMilliseconds = "grpc.server.latency.milliseconds"
 )
 
+// Metric names reported by the MetricsReporter when WithSemanticConventions
+// is enabled, matching the OpenTelemetry RPC metrics semantic conventions.
+const (
+	SemConvServerDuration        = "rpc.server.duration"
+	SemConvServerRequestSize     = "rpc.server.request.size"
+	SemConvServerResponseSize    = "rpc.server.response.size"
+	SemConvServerRequestsPerRPC  = "rpc.server.requests_per_rpc"
+	SemConvServerResponsesPerRPC = "rpc.server.responses_per_rpc"
+)
+
+const rpcSystemGRPC = "grpc"
+
 // MetricsReporter exposes methods to record metrics for gRPC servers.
 type MetricsReporter struct {
-	serverMsgReceivedCounter  metric.Int64UpDownCounter
+	config config
+
+	serverMsgReceivedCounter  metric.Int64Counter
 	serverMsgReceivedBytes    metric.Int64ValueRecorder
-	serverMsgSentCounter      metric.Int64UpDownCounter
+	serverMsgSentCounter      metric.Int64Counter
 	serverMsgSentBytes        metric.Int64ValueRecorder
 	serverLatencyMilliseconds metric.Int64ValueRecorder
+
+	semConvServerDuration        metric.Int64ValueRecorder
+	semConvServerRequestSize     metric.Int64ValueRecorder
+	semConvServerResponseSize    metric.Int64ValueRecorder
+	semConvServerRequestsPerRPC  metric.Int64ValueRecorder
+	semConvServerResponsesPerRPC metric.Int64ValueRecorder
+
+	clientMsgSentCounter      metric.Int64Counter
+	clientMsgSentBytes        metric.Int64ValueRecorder
+	clientMsgReceivedCounter  metric.Int64Counter
+	clientMsgReceivedBytes    metric.Int64ValueRecorder
+	clientLatencyMilliseconds metric.Int64ValueRecorder
 }
 
 // NewMetricsReporter uses the MeterProvider to register metrics and
 // collect them in a MetricsReporter instance, that can be used with grpc.NewServer
 // for instrumenting communication.
-func NewMetricsReporter(meterProvider metric.MeterProvider) (*MetricsReporter, error) {
-	meter := meterProvider.Meter(instrumentationName)
+func NewMetricsReporter(meterProvider metric.MeterProvider, opts ...ReporterOption) (*MetricsReporter, error) {
+	var cfg config
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+
+	meter := cfg.meter
+	if !cfg.hasMeter {
+		meter = meterProvider.Meter(instrumentationName)
+	}
 
 	withMessage := func(err error, metric string) error {
 		return fmt.Errorf("otelgrpc: failed to register metric '%s': %w", metric, err)
 	}
 
-	serverMsgReceivedCounter, err := meter.NewInt64UpDownCounter(
+	serverMsgReceivedCounter, err := meter.NewInt64Counter(
 		ServerMsgReceivedCounter,
 		metric.WithDescription("Total number of messages received on the server"),
 	)
-
 	if err != nil {
 		return nil, withMessage(err, ServerMsgReceivedCounter)
 	}
 
 	serverMsgReceivedBytes, err := meter.NewInt64ValueRecorder(
 		ServerMsgReceivedBytes,
-		metric.WithDescription("Number of bytes received by the server"),
+		cfg.sizeInstrumentOptions("Number of bytes received by the server")...,
 	)
-
 	if err != nil {
 		return nil, withMessage(err, ServerMsgReceivedBytes)
 	}
 
-	serverMsgSentCounter, err := meter.NewInt64UpDownCounter(
+	serverMsgSentCounter, err := meter.NewInt64Counter(
 		ServerMsgSentCounter,
 		metric.WithDescription("Total number of messages sent by the server"),
 	)
-
 	if err != nil {
 		return nil, withMessage(err, ServerMsgSentCounter)
 	}
 
 	serverMsgSentBytes, err := meter.NewInt64ValueRecorder(
 		ServerMsgSentBytes,
-		metric.WithDescription("Number of bytes sent by the server"),
+		cfg.sizeInstrumentOptions("Number of bytes sent by the server")...,
 	)
-
 	if err != nil {
 		return nil, withMessage(err, ServerMsgSentBytes)
 	}
 
 	serverLatencyMilliseconds, err := meter.NewInt64ValueRecorder(
 		ServerLatencyMilliseconds,
-		metric.WithDescription("Latency recorded by the server to handle a gRPC request"),
+		cfg.latencyInstrumentOptions("Latency recorded by the server to handle a gRPC request")...,
 	)
-
 	if err != nil {
 		return nil, withMessage(err, ServerLatencyMilliseconds)
 	}
 
-	return &MetricsReporter{
+	clientMsgSentCounter, err := meter.NewInt64Counter(
+		ClientMsgSentCounter,
+		metric.WithDescription("Total number of messages sent by the client"),
+	)
+	if err != nil {
+		return nil, withMessage(err, ClientMsgSentCounter)
+	}
+
+	clientMsgSentBytes, err := meter.NewInt64ValueRecorder(
+		ClientMsgSentBytes,
+		cfg.sizeInstrumentOptions("Number of bytes sent by the client")...,
+	)
+	if err != nil {
+		return nil, withMessage(err, ClientMsgSentBytes)
+	}
+
+	clientMsgReceivedCounter, err := meter.NewInt64Counter(
+		ClientMsgReceivedCounter,
+		metric.WithDescription("Total number of messages received by the client"),
+	)
+	if err != nil {
+		return nil, withMessage(err, ClientMsgReceivedCounter)
+	}
+
+	clientMsgReceivedBytes, err := meter.NewInt64ValueRecorder(
+		ClientMsgReceivedBytes,
+		cfg.sizeInstrumentOptions("Number of bytes received by the client")...,
+	)
+	if err != nil {
+		return nil, withMessage(err, ClientMsgReceivedBytes)
+	}
+
+	clientLatencyMilliseconds, err := meter.NewInt64ValueRecorder(
+		ClientLatencyMilliseconds,
+		cfg.latencyInstrumentOptions("Latency recorded by the client for a gRPC call to complete")...,
+	)
+	if err != nil {
+		return nil, withMessage(err, ClientLatencyMilliseconds)
+	}
+
+	mr := &MetricsReporter{
+		config: cfg,
+
 		serverMsgReceivedCounter:  serverMsgReceivedCounter,
 		serverMsgReceivedBytes:    serverMsgReceivedBytes,
 		serverMsgSentCounter:      serverMsgSentCounter,
 		serverMsgSentBytes:        serverMsgSentBytes,
 		serverLatencyMilliseconds: serverLatencyMilliseconds,
-	}, nil
+
+		clientMsgSentCounter:      clientMsgSentCounter,
+		clientMsgSentBytes:        clientMsgSentBytes,
+		clientMsgReceivedCounter:  clientMsgReceivedCounter,
+		clientMsgReceivedBytes:    clientMsgReceivedBytes,
+		clientLatencyMilliseconds: clientLatencyMilliseconds,
+	}
+
+	if !cfg.semanticConventions {
+		return mr, nil
+	}
+
+	semConvServerDuration, err := meter.NewInt64ValueRecorder(
+		SemConvServerDuration,
+		cfg.latencyInstrumentOptions("The duration of an inbound RPC, as seen by the server")...,
+	)
+	if err != nil {
+		return nil, withMessage(err, SemConvServerDuration)
+	}
+
+	semConvServerRequestSize, err := meter.NewInt64ValueRecorder(
+		SemConvServerRequestSize,
+		cfg.sizeInstrumentOptions("Size of the request message")...,
+	)
+	if err != nil {
+		return nil, withMessage(err, SemConvServerRequestSize)
+	}
+
+	semConvServerResponseSize, err := meter.NewInt64ValueRecorder(
+		SemConvServerResponseSize,
+		cfg.sizeInstrumentOptions("Size of the response message")...,
+	)
+	if err != nil {
+		return nil, withMessage(err, SemConvServerResponseSize)
+	}
+
+	semConvServerRequestsPerRPC, err := meter.NewInt64ValueRecorder(
+		SemConvServerRequestsPerRPC,
+		metric.WithDescription("Number of messages received per RPC"),
+	)
+	if err != nil {
+		return nil, withMessage(err, SemConvServerRequestsPerRPC)
+	}
+
+	semConvServerResponsesPerRPC, err := meter.NewInt64ValueRecorder(
+		SemConvServerResponsesPerRPC,
+		metric.WithDescription("Number of messages sent per RPC"),
+	)
+	if err != nil {
+		return nil, withMessage(err, SemConvServerResponsesPerRPC)
+	}
+
+	mr.semConvServerDuration = semConvServerDuration
+	mr.semConvServerRequestSize = semConvServerRequestSize
+	mr.semConvServerResponseSize = semConvServerResponseSize
+	mr.semConvServerRequestsPerRPC = semConvServerRequestsPerRPC
+	mr.semConvServerResponsesPerRPC = semConvServerResponsesPerRPC
+
+	return mr, nil
+}
+
+// peerAttributes resolves net.peer.name from ctx's peer information, when
+// available. net.peer.port is deliberately omitted: for server-side RPCs
+// it's the client's ephemeral source port, a new value per connection, and
+// attaching it here would blow up attribute cardinality in a way
+// WithAttributeFilter (which only sees the method/service attributes) can't
+// suppress.
+func peerAttributes(ctx context.Context) []attribute.KeyValue {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return nil
+	}
+
+	return []attribute.KeyValue{
+		attribute.String("net.peer.name", host),
+	}
+}
+
+// methodAttributes returns the 'rpc.method'/'rpc.service' attributes for
+// fullMethod, routed through the configured WithAttributeFilter when set.
+func (mr *MetricsReporter) methodAttributes(fullMethod string) []attribute.KeyValue {
+	if mr.config.attributeFilter != nil {
+		return mr.config.attributeFilter(fullMethod)
+	}
+
+	_, attributes := parseFullMethod(fullMethod)
+	return attributes
 }
 
 // UnaryServerInterceptor returns a grpc.UnaryServerInterceptor suitable
 // for use in a grpc.NewServer call.
+//
+// Exemplar linking (attaching the sampled span active on ctx to a recorded
+// histogram point) is out of scope here: exemplar reservoirs were added to
+// the OTel SDK's stable Histogram/Counter API, not the pre-1.0
+// Int64ValueRecorder/Int64Counter API this package is still built on.
+// An earlier attempt emulated it by duplicating trace_id/span_id into
+// regular attributes, which turns every sampled request into its own
+// permanent series and was reverted; there's no equivalent to reach for
+// until this package moves to the stable metric API.
 func (mr *MetricsReporter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -105,13 +279,22 @@ func (mr *MetricsReporter) UnaryServerInterceptor() grpc.UnaryServerInterceptor
 	) (interface{}, error) {
 		start := time.Now()
 
-		// These attributes contain 'rpc.method' and 'rpc.service.
-		_, attributes := parseFullMethod(info.FullMethod)
+		// These attributes normally contain 'rpc.method' and 'rpc.service',
+		// unless WithAttributeFilter trims them down for cardinality reasons.
+		attributes := mr.methodAttributes(info.FullMethod)
+		attributes = append(attributes, attribute.String("rpc.system", rpcSystemGRPC))
+		attributes = append(attributes, peerAttributes(ctx)...)
 
-		mr.serverMsgReceivedCounter.Add(ctx, 1, attributes...)
+		if !mr.config.isDisabled(ServerMsgReceivedCounter) {
+			mr.serverMsgReceivedCounter.Add(ctx, 1, attributes...)
+		}
 
+		var reqSize int64
 		if p, ok := req.(proto.Message); ok {
-			mr.serverMsgReceivedBytes.Record(ctx, int64(proto.Size(p)), attributes...)
+			reqSize = int64(proto.Size(p))
+			if !mr.config.isDisabled(ServerMsgReceivedBytes) {
+				mr.serverMsgReceivedBytes.Record(ctx, reqSize, attributes...)
+			}
 		}
 
 		code := grpc_codes.OK
@@ -119,17 +302,33 @@ func (mr *MetricsReporter) UnaryServerInterceptor() grpc.UnaryServerInterceptor
 		latency := time.Since(start)
 
 		if err != nil {
-			status, _ := status.FromError(err)
-			code = status.Code()
+			s, _ := status.FromError(err)
+			code = s.Code()
 		}
 
 		attributes = append(attributes, statusCodeAttr(code))
 
-		mr.serverMsgSentCounter.Add(ctx, 1, attributes...)
-		mr.serverLatencyMilliseconds.Record(ctx, latency.Milliseconds(), attributes...)
+		if !mr.config.isDisabled(ServerMsgSentCounter) {
+			mr.serverMsgSentCounter.Add(ctx, 1, attributes...)
+		}
+		if !mr.config.isDisabled(ServerLatencyMilliseconds) {
+			mr.serverLatencyMilliseconds.Record(ctx, latency.Milliseconds(), attributes...)
+		}
 
+		var respSize int64
 		if p, ok := resp.(proto.Message); ok {
-			mr.serverMsgSentBytes.Record(ctx, int64(proto.Size(p)), attributes...)
+			respSize = int64(proto.Size(p))
+			if !mr.config.isDisabled(ServerMsgSentBytes) {
+				mr.serverMsgSentBytes.Record(ctx, respSize, attributes...)
+			}
+		}
+
+		if mr.config.semanticConventions {
+			mr.semConvServerDuration.Record(ctx, latency.Milliseconds(), attributes...)
+			mr.semConvServerRequestSize.Record(ctx, reqSize, attributes...)
+			mr.semConvServerResponseSize.Record(ctx, respSize, attributes...)
+			mr.semConvServerRequestsPerRPC.Record(ctx, 1, attributes...)
+			mr.semConvServerResponsesPerRPC.Record(ctx, 1, attributes...)
 		}
 
 		return resp, err
@@ -0,0 +1,189 @@
+package otelgrpc
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto" // nolint:staticcheck
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
+	grpc_codes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor returns a grpc.UnaryClientInterceptor suitable for
+// use in a grpc.Dial/grpc.NewClient call, recording the client-side
+// counterpart of the metrics UnaryServerInterceptor records.
+func (mr *MetricsReporter) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+
+		attributes := mr.methodAttributes(method)
+		attributes = append(attributes,
+			attribute.String("rpc.system", rpcSystemGRPC),
+			attribute.String("net.peer.name", cc.Target()),
+		)
+
+		if !mr.config.isDisabled(ClientMsgSentCounter) {
+			mr.clientMsgSentCounter.Add(ctx, 1, attributes...)
+		}
+		if p, ok := req.(proto.Message); ok && !mr.config.isDisabled(ClientMsgSentBytes) {
+			mr.clientMsgSentBytes.Record(ctx, int64(proto.Size(p)), attributes...)
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		latency := time.Since(start)
+
+		code := grpc_codes.OK
+		if err != nil {
+			s, _ := status.FromError(err)
+			code = s.Code()
+		}
+		attributes = append(attributes, statusCodeAttr(code))
+
+		if !mr.config.isDisabled(ClientMsgReceivedCounter) {
+			mr.clientMsgReceivedCounter.Add(ctx, 1, attributes...)
+		}
+		if !mr.config.isDisabled(ClientLatencyMilliseconds) {
+			mr.clientLatencyMilliseconds.Record(ctx, latency.Milliseconds(), attributes...)
+		}
+
+		if p, ok := reply.(proto.Message); ok && !mr.config.isDisabled(ClientMsgReceivedBytes) {
+			mr.clientMsgReceivedBytes.Record(ctx, int64(proto.Size(p)), attributes...)
+		}
+
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a grpc.StreamClientInterceptor suitable
+// for use in a grpc.Dial/grpc.NewClient call. Per-message counters are
+// recorded for every SendMsg/RecvMsg, so throughput on long-lived streams is
+// represented accurately, and latency/status are recorded once the stream
+// terminates.
+func (mr *MetricsReporter) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		start := time.Now()
+
+		attributes := mr.methodAttributes(method)
+		attributes = append(attributes,
+			attribute.String("rpc.system", rpcSystemGRPC),
+			attribute.String("net.peer.name", cc.Target()),
+		)
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			if !mr.config.isDisabled(ClientLatencyMilliseconds) {
+				s, _ := status.FromError(err)
+				attrs := append(attributes, statusCodeAttr(s.Code()))
+				mr.clientLatencyMilliseconds.Record(ctx, time.Since(start).Milliseconds(), attrs...)
+			}
+			return nil, err
+		}
+
+		return &metricsClientStream{
+			ClientStream: stream,
+			reporter:     mr,
+			ctx:          ctx,
+			start:        start,
+			attributes:   attributes,
+		}, nil
+	}
+}
+
+// metricsClientStream wraps a grpc.ClientStream so that every SendMsg and
+// RecvMsg call updates the reporter's per-message counters, and the stream's
+// terminal EOF/error updates the latency and status code once.
+type metricsClientStream struct {
+	grpc.ClientStream
+
+	reporter   *MetricsReporter
+	ctx        context.Context
+	start      time.Time
+	attributes []attribute.KeyValue
+
+	// finished guards against a double-recorded terminal metric. grpc.ClientStream
+	// explicitly permits SendMsg and RecvMsg to be called concurrently from
+	// separate goroutines, so a send error and a recv EOF/error can race to
+	// call finish; access it only through atomic.CompareAndSwapInt32.
+	finished int32
+}
+
+func (s *metricsClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err != nil {
+		// A SendMsg error aborts the stream, and callers commonly stop here
+		// without ever calling RecvMsg again to observe the io.EOF/error
+		// that would otherwise trigger finish, so record it here instead.
+		s.finish(err)
+		return err
+	}
+
+	if !s.reporter.config.isDisabled(ClientMsgSentCounter) {
+		s.reporter.clientMsgSentCounter.Add(s.ctx, 1, s.attributes...)
+	}
+	if p, ok := m.(proto.Message); ok && !s.reporter.config.isDisabled(ClientMsgSentBytes) {
+		s.reporter.clientMsgSentBytes.Record(s.ctx, int64(proto.Size(p)), s.attributes...)
+	}
+
+	return nil
+}
+
+func (s *metricsClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+
+	switch err {
+	case nil:
+		if !s.reporter.config.isDisabled(ClientMsgReceivedCounter) {
+			s.reporter.clientMsgReceivedCounter.Add(s.ctx, 1, s.attributes...)
+		}
+		if p, ok := m.(proto.Message); ok && !s.reporter.config.isDisabled(ClientMsgReceivedBytes) {
+			s.reporter.clientMsgReceivedBytes.Record(s.ctx, int64(proto.Size(p)), s.attributes...)
+		}
+	case io.EOF:
+		s.finish(nil)
+	default:
+		s.finish(err)
+	}
+
+	return err
+}
+
+// finish records the stream's final latency and status code exactly once,
+// since RecvMsg keeps being called with io.EOF/errors after the stream ends,
+// and a concurrent SendMsg error can race with that. The CompareAndSwap is
+// what makes "exactly once" hold under that race, not just a bool check.
+func (s *metricsClientStream) finish(err error) {
+	if !atomic.CompareAndSwapInt32(&s.finished, 0, 1) {
+		return
+	}
+
+	code := grpc_codes.OK
+	if err != nil {
+		st, _ := status.FromError(err)
+		code = st.Code()
+	}
+
+	if s.reporter.config.isDisabled(ClientLatencyMilliseconds) {
+		return
+	}
+
+	attributes := append(s.attributes, statusCodeAttr(code))
+	s.reporter.clientLatencyMilliseconds.Record(s.ctx, time.Since(s.start).Milliseconds(), attributes...)
+}
@@ -0,0 +1,146 @@
+package otelgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeClientStream is a grpc.ClientStream double that lets tests control the
+// error SendMsg/RecvMsg return, without needing a real transport.
+type fakeClientStream struct {
+	sendErr error
+	recvErr error
+}
+
+func (f *fakeClientStream) Header() (metadata.MD, error) { return nil, nil }
+func (f *fakeClientStream) Trailer() metadata.MD         { return nil }
+func (f *fakeClientStream) CloseSend() error             { return nil }
+func (f *fakeClientStream) Context() context.Context     { return context.Background() }
+func (f *fakeClientStream) SendMsg(m interface{}) error  { return f.sendErr }
+func (f *fakeClientStream) RecvMsg(m interface{}) error  { return f.recvErr }
+
+// allDisabledReporter returns a MetricsReporter whose instruments are never
+// touched, so tests can exercise metricsClientStream's and StatsHandler's
+// control flow without a real MeterProvider.
+func allDisabledReporter() *MetricsReporter {
+	return &MetricsReporter{
+		config: config{
+			disabledMetrics: map[MetricName]struct{}{
+				MetricServerMsgReceivedCounter:  {},
+				MetricServerMsgReceivedBytes:    {},
+				MetricServerMsgSentCounter:      {},
+				MetricServerMsgSentBytes:        {},
+				MetricServerLatencyMilliseconds: {},
+				MetricClientMsgSentCounter:      {},
+				MetricClientMsgSentBytes:        {},
+				MetricClientMsgReceivedCounter:  {},
+				MetricClientMsgReceivedBytes:    {},
+				MetricClientLatencyMilliseconds: {},
+			},
+		},
+	}
+}
+
+func TestMetricsClientStreamSendMsgErrorTriggersFinish(t *testing.T) {
+	stream := &metricsClientStream{
+		ClientStream: &fakeClientStream{sendErr: errors.New("send failed")},
+		reporter:     allDisabledReporter(),
+		ctx:          context.Background(),
+		start:        time.Now(),
+	}
+
+	if err := stream.SendMsg("payload"); err == nil {
+		t.Fatal("expected SendMsg to return the underlying error")
+	}
+	if atomic.LoadInt32(&stream.finished) == 0 {
+		t.Fatal("a SendMsg error aborts the stream and must record the terminal metric, since the caller may never call RecvMsg again")
+	}
+}
+
+func TestMetricsClientStreamSendMsgSuccessDoesNotFinish(t *testing.T) {
+	stream := &metricsClientStream{
+		ClientStream: &fakeClientStream{},
+		reporter:     allDisabledReporter(),
+		ctx:          context.Background(),
+		start:        time.Now(),
+	}
+
+	if err := stream.SendMsg("payload"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if atomic.LoadInt32(&stream.finished) != 0 {
+		t.Fatal("a successful SendMsg must not finish the stream; it may still be open")
+	}
+}
+
+func TestMetricsClientStreamRecvMsgEOFTriggersFinish(t *testing.T) {
+	stream := &metricsClientStream{
+		ClientStream: &fakeClientStream{recvErr: io.EOF},
+		reporter:     allDisabledReporter(),
+		ctx:          context.Background(),
+		start:        time.Now(),
+	}
+
+	if err := stream.RecvMsg(&struct{}{}); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+	if atomic.LoadInt32(&stream.finished) == 0 {
+		t.Fatal("RecvMsg returning io.EOF must finish the stream")
+	}
+}
+
+func TestMetricsClientStreamFinishIsIdempotent(t *testing.T) {
+	stream := &metricsClientStream{
+		reporter: allDisabledReporter(),
+		ctx:      context.Background(),
+		start:    time.Now(),
+	}
+
+	stream.finish(nil)
+	if atomic.LoadInt32(&stream.finished) == 0 {
+		t.Fatal("expected finish to mark the stream finished")
+	}
+
+	// A second call must be a no-op: with nothing disabled any longer, it must
+	// not panic even though the reporter's instruments are unset, proving
+	// finish didn't touch them.
+	stream.reporter = &MetricsReporter{}
+	stream.finish(errors.New("ignored, stream already finished"))
+}
+
+// TestMetricsClientStreamFinishConcurrent exercises the documented
+// grpc.ClientStream contract that SendMsg and RecvMsg may run on separate
+// goroutines: a send error and a recv EOF/error racing to call finish must
+// still record the terminal metric exactly once. Run with -race to catch a
+// regression back to a plain bool guard.
+func TestMetricsClientStreamFinishConcurrent(t *testing.T) {
+	stream := &metricsClientStream{
+		ClientStream: &fakeClientStream{sendErr: errors.New("send failed"), recvErr: io.EOF},
+		reporter:     allDisabledReporter(),
+		ctx:          context.Background(),
+		start:        time.Now(),
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_ = stream.SendMsg("payload")
+	}()
+	go func() {
+		defer wg.Done()
+		_ = stream.RecvMsg(&struct{}{})
+	}()
+	wg.Wait()
+
+	if atomic.LoadInt32(&stream.finished) == 0 {
+		t.Fatal("expected the stream to be finished after concurrent SendMsg/RecvMsg termination")
+	}
+}
@@ -0,0 +1,137 @@
+package otelgrpc
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+// MetricName identifies one of the metrics a MetricsReporter can record, for
+// use with WithDisabledMetrics.
+type MetricName string
+
+// The metrics a MetricsReporter can record, suitable for WithDisabledMetrics.
+const (
+	MetricServerMsgReceivedCounter  MetricName = ServerMsgReceivedCounter
+	MetricServerMsgReceivedBytes    MetricName = ServerMsgReceivedBytes
+	MetricServerMsgSentCounter      MetricName = ServerMsgSentCounter
+	MetricServerMsgSentBytes        MetricName = ServerMsgSentBytes
+	MetricServerLatencyMilliseconds MetricName = ServerLatencyMilliseconds
+	MetricClientMsgSentCounter      MetricName = ClientMsgSentCounter
+	MetricClientMsgSentBytes        MetricName = ClientMsgSentBytes
+	MetricClientMsgReceivedCounter  MetricName = ClientMsgReceivedCounter
+	MetricClientMsgReceivedBytes    MetricName = ClientMsgReceivedBytes
+	MetricClientLatencyMilliseconds MetricName = ClientLatencyMilliseconds
+)
+
+// config holds the resolved state of every ReporterOption passed to
+// NewMetricsReporter.
+type config struct {
+	semanticConventions bool
+
+	meter             metric.Meter
+	hasMeter          bool
+	latencyBoundaries []float64
+	sizeBoundaries    []float64
+	attributeFilter   func(fullMethod string) []attribute.KeyValue
+	disabledMetrics   map[MetricName]struct{}
+}
+
+// isDisabled reports whether name was passed to WithDisabledMetrics.
+func (c *config) isDisabled(name string) bool {
+	_, ok := c.disabledMetrics[MetricName(name)]
+	return ok
+}
+
+// latencyInstrumentOptions returns the instrument options for a latency
+// histogram-like ValueRecorder, honoring WithLatencyBoundaries when set.
+func (c *config) latencyInstrumentOptions(description string) []metric.Option {
+	opts := []metric.Option{
+		metric.WithDescription(description),
+		metric.WithUnit(unit.Milliseconds),
+	}
+	if len(c.latencyBoundaries) > 0 {
+		opts = append(opts, metric.WithExplicitBoundaries(c.latencyBoundaries))
+	}
+	return opts
+}
+
+// sizeInstrumentOptions returns the instrument options for a byte-size
+// histogram-like ValueRecorder, honoring WithSizeBoundaries when set.
+func (c *config) sizeInstrumentOptions(description string) []metric.Option {
+	opts := []metric.Option{
+		metric.WithDescription(description),
+		metric.WithUnit(unit.Bytes),
+	}
+	if len(c.sizeBoundaries) > 0 {
+		opts = append(opts, metric.WithExplicitBoundaries(c.sizeBoundaries))
+	}
+	return opts
+}
+
+// ReporterOption configures optional behavior of a MetricsReporter.
+type ReporterOption interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (f optionFunc) apply(c *config) { f(c) }
+
+// WithSemanticConventions additionally emits, alongside the legacy
+// grpc.server.* metrics, a set of instruments named after the
+// OpenTelemetry RPC metrics semantic conventions (rpc.server.duration and
+// friends), so dashboards can be migrated without an instrumentation
+// cutover.
+func WithSemanticConventions(enabled bool) ReporterOption {
+	return optionFunc(func(c *config) { c.semanticConventions = enabled })
+}
+
+// WithMeter uses meter instead of deriving one from the MeterProvider passed
+// to NewMetricsReporter, for callers that already hold a pre-configured
+// Meter.
+func WithMeter(meter metric.Meter) ReporterOption {
+	return optionFunc(func(c *config) {
+		c.meter = meter
+		c.hasMeter = true
+	})
+}
+
+// WithLatencyBoundaries overrides the histogram bucket boundaries used by
+// every latency instrument, which otherwise fall back to the SDK default.
+// Tune this to match your latency SLOs.
+func WithLatencyBoundaries(boundaries []float64) ReporterOption {
+	return optionFunc(func(c *config) { c.latencyBoundaries = boundaries })
+}
+
+// WithSizeBoundaries overrides the histogram bucket boundaries used by every
+// message-size instrument, which otherwise fall back to the SDK default.
+func WithSizeBoundaries(boundaries []float64) ReporterOption {
+	return optionFunc(func(c *config) { c.sizeBoundaries = boundaries })
+}
+
+// WithAttributeFilter replaces the default 'rpc.method'/'rpc.service'
+// attributes derived from the full method name with whatever filter
+// returns, letting callers drop high-cardinality attributes. Methods that
+// the filter reduces to no attributes still contribute to the metric's
+// aggregated total, so operators keep a coarse signal without paying the
+// cardinality cost.
+func WithAttributeFilter(filter func(fullMethod string) []attribute.KeyValue) ReporterOption {
+	return optionFunc(func(c *config) { c.attributeFilter = filter })
+}
+
+// WithDisabledMetrics suppresses recording of the given metrics entirely,
+// e.g. to drop per-message counters on high-throughput streaming services.
+// The instruments are still registered; the set of disabled metrics is fixed
+// once NewMetricsReporter returns. Only the MetricName constants above are
+// honored: the metrics added by WithSemanticConventions are always recorded.
+func WithDisabledMetrics(names ...MetricName) ReporterOption {
+	return optionFunc(func(c *config) {
+		if c.disabledMetrics == nil {
+			c.disabledMetrics = make(map[MetricName]struct{}, len(names))
+		}
+		for _, name := range names {
+			c.disabledMetrics[name] = struct{}{}
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package otelgrpc
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestConfigIsDisabled(t *testing.T) {
+	c := &config{}
+	if c.isDisabled(ClientMsgSentCounter) {
+		t.Fatal("nothing should be disabled by default")
+	}
+
+	WithDisabledMetrics(MetricClientMsgSentCounter).apply(c)
+
+	if !c.isDisabled(ClientMsgSentCounter) {
+		t.Fatal("expected ClientMsgSentCounter to be disabled")
+	}
+	if c.isDisabled(ClientMsgReceivedCounter) {
+		t.Fatal("WithDisabledMetrics must not disable metrics it wasn't given")
+	}
+}
+
+func TestWithDisabledMetricsAccumulates(t *testing.T) {
+	c := &config{}
+	WithDisabledMetrics(MetricClientMsgSentCounter).apply(c)
+	WithDisabledMetrics(MetricClientMsgReceivedCounter).apply(c)
+
+	if !c.isDisabled(ClientMsgSentCounter) || !c.isDisabled(ClientMsgReceivedCounter) {
+		t.Fatal("repeated WithDisabledMetrics options should accumulate, not overwrite each other")
+	}
+}
+
+func TestMethodAttributesUsesFilter(t *testing.T) {
+	want := attribute.String("rpc.method", "redacted")
+	mr := &MetricsReporter{
+		config: config{
+			attributeFilter: func(fullMethod string) []attribute.KeyValue {
+				return []attribute.KeyValue{want}
+			},
+		},
+	}
+
+	got := mr.methodAttributes("/svc.Service/Method")
+
+	if len(got) != 1 || got[0] != want {
+		t.Fatalf("expected attributeFilter's result to be used verbatim, got %v", got)
+	}
+}
@@ -0,0 +1,45 @@
+package otelgrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/peer"
+)
+
+func TestPeerAttributesOmitsPort(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 54321}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+
+	attrs := peerAttributes(ctx)
+
+	if len(attrs) != 1 {
+		t.Fatalf("expected exactly one attribute, got %v", attrs)
+	}
+	if string(attrs[0].Key) != "net.peer.name" {
+		t.Fatalf("expected net.peer.name, got %q", attrs[0].Key)
+	}
+	if got := attrs[0].Value.AsString(); got != "10.0.0.5" {
+		t.Fatalf("expected host 10.0.0.5, got %q", got)
+	}
+
+	for _, a := range attrs {
+		if string(a.Key) == "net.peer.port" {
+			t.Fatal("net.peer.port must not be attached to metric attributes: it's a new value per connection and blows up cardinality")
+		}
+	}
+}
+
+func TestPeerAttributesNoPeer(t *testing.T) {
+	if attrs := peerAttributes(context.Background()); attrs != nil {
+		t.Fatalf("expected no attributes without peer info in ctx, got %v", attrs)
+	}
+}
+
+func TestPeerAttributesNilAddr(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{})
+	if attrs := peerAttributes(ctx); attrs != nil {
+		t.Fatalf("expected no attributes for a peer.Peer with a nil Addr, got %v", attrs)
+	}
+}
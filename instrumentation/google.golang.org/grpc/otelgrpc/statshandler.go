@@ -0,0 +1,186 @@
+package otelgrpc
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	grpc_codes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// Metric names reported by the StatsHandler for outbound, client-side RPCs.
+// Servers keep using the ServerXxx names declared in grpcmetric.go.
+const (
+	ClientMsgSentCounter      = "grpc.client.msg.sent.total"
+	ClientMsgSentBytes        = "grpc.client.msg.sent.bytes"
+	ClientMsgReceivedCounter  = "grpc.client.msg.received.total"
+	ClientMsgReceivedBytes    = "grpc.client.msg.received.bytes"
+	ClientLatencyMilliseconds = "grpc.client.latency.milliseconds"
+)
+
+// StatsHandler implements grpc/stats.Handler, recording metrics for both
+// server-side and client-side RPCs, including every message exchanged over a
+// streaming call. Install it with grpc.StatsHandler(...) on a grpc.NewServer
+// or a grpc.NewClient.
+//
+// It records through a MetricsReporter that NewStatsHandler creates, so it
+// uses the same metric names, instruments and options as the interceptors.
+// NewStatsHandler registers that reporter's instruments itself; it does not
+// reuse the instruments of a MetricsReporter created separately.
+type StatsHandler struct {
+	reporter *MetricsReporter
+}
+
+// NewStatsHandler uses the MeterProvider to register metrics and returns a
+// StatsHandler that can be attached to a grpc.NewServer or a grpc.NewClient
+// to instrument unary and streaming RPCs alike. opts configures the
+// underlying MetricsReporter, so WithSemanticConventions, WithAttributeFilter,
+// WithDisabledMetrics and the boundary options all apply here too.
+func NewStatsHandler(meterProvider metric.MeterProvider, opts ...ReporterOption) (*StatsHandler, error) {
+	reporter, err := NewMetricsReporter(meterProvider, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &StatsHandler{reporter: reporter}, nil
+}
+
+type rpcStatsKey struct{}
+
+// connPeerKey stashes the peer host TagConn observed, so TagRPC (whose ctx is
+// derived from the one TagConn returns) can attach it as net.peer.name.
+type connPeerKey struct{}
+
+// rpcInfo carries the per-RPC state between TagRPC and the HandleRPC calls
+// that follow it. requestCount/responseCount are accessed with the atomic
+// package, since a streaming RPC can have SendMsg and RecvMsg in flight on
+// separate goroutines, each driving its own InPayload/OutPayload callbacks.
+type rpcInfo struct {
+	startTime  time.Time
+	attributes []attribute.KeyValue
+
+	requestCount  int64
+	responseCount int64
+}
+
+// TagRPC stashes the method/service attributes and start time for the RPC in
+// the context, so the HandleRPC calls that follow can annotate the metrics
+// they record. Attributes go through the reporter's methodAttributes, so
+// WithAttributeFilter applies here exactly as it does to the interceptors.
+func (h *StatsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	attributes := h.reporter.methodAttributes(info.FullMethodName)
+	attributes = append(attributes, attribute.String("rpc.system", rpcSystemGRPC))
+	if host, ok := ctx.Value(connPeerKey{}).(string); ok {
+		attributes = append(attributes, attribute.String("net.peer.name", host))
+	}
+	return context.WithValue(ctx, rpcStatsKey{}, &rpcInfo{
+		startTime:  time.Now(),
+		attributes: attributes,
+	})
+}
+
+// HandleRPC records metrics for the lifecycle events of a single RPC. Unlike
+// a unary interceptor, it sees every stats.InPayload/stats.OutPayload on a
+// streaming call, so per-message counters stay accurate for streams too.
+func (h *StatsHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	info, ok := ctx.Value(rpcStatsKey{}).(*rpcInfo)
+	if !ok {
+		return
+	}
+	cfg := &h.reporter.config
+
+	switch rs := rs.(type) {
+	case *stats.InPayload:
+		if rs.Client {
+			if !cfg.isDisabled(ClientMsgReceivedCounter) {
+				h.reporter.clientMsgReceivedCounter.Add(ctx, 1, info.attributes...)
+			}
+			if !cfg.isDisabled(ClientMsgReceivedBytes) {
+				h.reporter.clientMsgReceivedBytes.Record(ctx, int64(rs.WireLength), info.attributes...)
+			}
+		} else {
+			if !cfg.isDisabled(ServerMsgReceivedCounter) {
+				h.reporter.serverMsgReceivedCounter.Add(ctx, 1, info.attributes...)
+			}
+			if !cfg.isDisabled(ServerMsgReceivedBytes) {
+				h.reporter.serverMsgReceivedBytes.Record(ctx, int64(rs.WireLength), info.attributes...)
+			}
+			atomic.AddInt64(&info.requestCount, 1)
+			if cfg.semanticConventions {
+				h.reporter.semConvServerRequestSize.Record(ctx, int64(rs.WireLength), info.attributes...)
+			}
+		}
+
+	case *stats.OutPayload:
+		if rs.Client {
+			if !cfg.isDisabled(ClientMsgSentCounter) {
+				h.reporter.clientMsgSentCounter.Add(ctx, 1, info.attributes...)
+			}
+			if !cfg.isDisabled(ClientMsgSentBytes) {
+				h.reporter.clientMsgSentBytes.Record(ctx, int64(rs.WireLength), info.attributes...)
+			}
+		} else {
+			if !cfg.isDisabled(ServerMsgSentCounter) {
+				h.reporter.serverMsgSentCounter.Add(ctx, 1, info.attributes...)
+			}
+			if !cfg.isDisabled(ServerMsgSentBytes) {
+				h.reporter.serverMsgSentBytes.Record(ctx, int64(rs.WireLength), info.attributes...)
+			}
+			atomic.AddInt64(&info.responseCount, 1)
+			if cfg.semanticConventions {
+				h.reporter.semConvServerResponseSize.Record(ctx, int64(rs.WireLength), info.attributes...)
+			}
+		}
+
+	case *stats.End:
+		code := grpc_codes.OK
+		if rs.Error != nil {
+			s, _ := status.FromError(rs.Error)
+			code = s.Code()
+		}
+		attributes := append(info.attributes, statusCodeAttr(code))
+		latency := time.Since(info.startTime)
+
+		if rs.Client {
+			if !cfg.isDisabled(ClientLatencyMilliseconds) {
+				h.reporter.clientLatencyMilliseconds.Record(ctx, latency.Milliseconds(), attributes...)
+			}
+			return
+		}
+
+		if !cfg.isDisabled(ServerLatencyMilliseconds) {
+			h.reporter.serverLatencyMilliseconds.Record(ctx, latency.Milliseconds(), attributes...)
+		}
+		if cfg.semanticConventions {
+			h.reporter.semConvServerDuration.Record(ctx, latency.Milliseconds(), attributes...)
+			h.reporter.semConvServerRequestsPerRPC.Record(ctx, atomic.LoadInt64(&info.requestCount), attributes...)
+			h.reporter.semConvServerResponsesPerRPC.Record(ctx, atomic.LoadInt64(&info.responseCount), attributes...)
+		}
+	}
+}
+
+// TagConn stashes the peer host from info.RemoteAddr in the returned
+// context, so every RPC on this connection can attach net.peer.name the same
+// way UnaryServerInterceptor does via peerAttributes.
+// This is the only place a stats.Handler observes connection/peer info; it
+// isn't itself connection-level telemetry, which is why it belongs here
+// rather than in HandleConn.
+func (h *StatsHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	if info.RemoteAddr == nil {
+		return ctx
+	}
+
+	host, _, err := net.SplitHostPort(info.RemoteAddr.String())
+	if err != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, connPeerKey{}, host)
+}
+
+// HandleConn is a no-op: this StatsHandler only reports per-RPC metrics, not
+// connection-level ones.
+func (h *StatsHandler) HandleConn(context.Context, stats.ConnStats) {}
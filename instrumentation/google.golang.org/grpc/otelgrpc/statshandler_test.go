@@ -0,0 +1,107 @@
+package otelgrpc
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc/stats"
+)
+
+// allDisabledStatsHandler returns a StatsHandler whose reporter has every
+// counter/byte metric disabled and semantic conventions off, so HandleRPC
+// exercises its client/server routing and tallying without ever calling a
+// real (unset) metric instrument.
+func allDisabledStatsHandler() *StatsHandler {
+	return &StatsHandler{reporter: allDisabledReporter()}
+}
+
+func TestStatsHandlerTagConnThenTagRPCAttachesPeerName(t *testing.T) {
+	h := allDisabledStatsHandler()
+
+	ctx := h.TagConn(context.Background(), &stats.ConnTagInfo{
+		RemoteAddr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 443},
+	})
+	ctx = h.TagRPC(ctx, &stats.RPCTagInfo{FullMethodName: "/svc.Service/Method"})
+
+	info, ok := ctx.Value(rpcStatsKey{}).(*rpcInfo)
+	if !ok {
+		t.Fatal("expected TagRPC to stash an rpcInfo in the context")
+	}
+
+	var found bool
+	for _, a := range info.attributes {
+		if string(a.Key) == "net.peer.name" {
+			found = true
+			if got := a.Value.AsString(); got != "10.0.0.7" {
+				t.Fatalf("expected net.peer.name=10.0.0.7, got %q", got)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected net.peer.name to be attached, derived from TagConn's RemoteAddr")
+	}
+}
+
+func TestStatsHandlerTagConnNilRemoteAddr(t *testing.T) {
+	h := allDisabledStatsHandler()
+
+	base := context.Background()
+	ctx := h.TagConn(base, &stats.ConnTagInfo{})
+	if ctx != base {
+		t.Fatal("expected TagConn to return ctx unchanged for a nil RemoteAddr, not panic")
+	}
+}
+
+func TestStatsHandlerTagRPCWithoutTagConnOmitsPeerName(t *testing.T) {
+	h := allDisabledStatsHandler()
+
+	ctx := h.TagRPC(context.Background(), &stats.RPCTagInfo{FullMethodName: "/svc.Service/Method"})
+	info := ctx.Value(rpcStatsKey{}).(*rpcInfo)
+
+	for _, a := range info.attributes {
+		if string(a.Key) == "net.peer.name" {
+			t.Fatal("no peer info was ever tagged, so net.peer.name should be absent, not empty")
+		}
+	}
+}
+
+func TestStatsHandlerHandleRPCTalliesPerMessageByClientServer(t *testing.T) {
+	h := allDisabledStatsHandler()
+	ctx := h.TagConn(context.Background(), &stats.ConnTagInfo{
+		RemoteAddr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 443},
+	})
+	ctx = h.TagRPC(ctx, &stats.RPCTagInfo{FullMethodName: "/svc.Service/Method"})
+
+	// Two server-side request messages and one client-side one (as seen by,
+	// say, a proxy sharing this handler): only the server-side ones should
+	// tally, and each call should add exactly one, not one per RPC.
+	h.HandleRPC(ctx, &stats.InPayload{Client: false, WireLength: 10})
+	h.HandleRPC(ctx, &stats.InPayload{Client: false, WireLength: 20})
+	h.HandleRPC(ctx, &stats.InPayload{Client: true, WireLength: 30})
+
+	// Three server-side response messages and one client-side one.
+	h.HandleRPC(ctx, &stats.OutPayload{Client: false, WireLength: 10})
+	h.HandleRPC(ctx, &stats.OutPayload{Client: false, WireLength: 20})
+	h.HandleRPC(ctx, &stats.OutPayload{Client: false, WireLength: 30})
+	h.HandleRPC(ctx, &stats.OutPayload{Client: true, WireLength: 40})
+
+	info := ctx.Value(rpcStatsKey{}).(*rpcInfo)
+
+	if got := atomic.LoadInt64(&info.requestCount); got != 2 {
+		t.Fatalf("expected requestCount=2 (server-side InPayloads only), got %d", got)
+	}
+	if got := atomic.LoadInt64(&info.responseCount); got != 3 {
+		t.Fatalf("expected responseCount=3 (server-side OutPayloads only), got %d", got)
+	}
+}
+
+func TestStatsHandlerHandleRPCIgnoresUntaggedContext(t *testing.T) {
+	h := allDisabledStatsHandler()
+
+	// No TagRPC was called, so HandleRPC must not panic looking up state
+	// that was never stashed.
+	h.HandleRPC(context.Background(), &stats.InPayload{Client: false, WireLength: 10})
+	h.HandleRPC(context.Background(), &stats.End{})
+}